@@ -2,11 +2,20 @@
 package nfpm
 
 import (
+	"crypto/md5"  // nolint:gosec
+	"crypto/sha1" // nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/log"
 	"github.com/goreleaser/goreleaser/internal/artifact"
@@ -25,6 +34,7 @@ import (
 	_ "github.com/goreleaser/nfpm/v2/apk"  // blank import to register the format
 	_ "github.com/goreleaser/nfpm/v2/arch" // blank import to register the format
 	_ "github.com/goreleaser/nfpm/v2/deb"  // blank import to register the format
+	_ "github.com/goreleaser/nfpm/v2/ipk"  // blank import to register the format
 	_ "github.com/goreleaser/nfpm/v2/rpm"  // blank import to register the format
 )
 
@@ -33,6 +43,31 @@ const (
 	extraFiles          = "Files"
 )
 
+// supportedFormats lists the packager formats registered via the blank
+// imports above. nfpm registers packagers into an unexported map with no
+// enumeration API, so there's no way to derive this list from the library
+// at runtime: adding a new nfpm packager still means a new blank import
+// plus a matching entry here.
+var supportedFormats = []string{"apk", archlinuxFormat, "deb", ipkFormat, "rpm", termuxFormat}
+
+// SupportedFormats returns the nfpm package formats this pipe knows how to
+// build, so other pipes (checksum, sign, publish) can iterate all linux
+// package artifacts without re-declaring the list themselves.
+func SupportedFormats() []string {
+	result := make([]string, len(supportedFormats))
+	copy(result, supportedFormats)
+	return result
+}
+
+func isSupportedFormat(format string) bool {
+	for _, f := range supportedFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // Pipe for nfpm packaging.
 type Pipe struct{}
 
@@ -62,6 +97,15 @@ func (Pipe) Default(ctx *context.Context) error {
 		if len(fpm.Replacements) != 0 {
 			deprecate.Notice(ctx, "nfpms.replacements")
 		}
+		for _, format := range fpm.Formats {
+			if !isSupportedFormat(format) {
+				return fmt.Errorf(
+					"invalid nfpm format: %s, supported formats are: %s",
+					format,
+					strings.Join(SupportedFormats(), ", "),
+				)
+			}
+		}
 		ids.Inc(fpm.ID)
 	}
 
@@ -100,13 +144,38 @@ func doRun(ctx *context.Context, fpm config.NFPM) error {
 	if len(linuxBinaries) == 0 {
 		return fmt.Errorf("no linux binaries found for builds %v", fpm.Builds)
 	}
+
+	mtime, err := resolveMTime(ctx, tmpl.New(ctx), fpm)
+	if err != nil {
+		return err
+	}
+	reproducible := fpm.Reproducible || !mtime.IsZero()
+	if reproducible && mtime.IsZero() {
+		// Neither nfpms.mtime nor SOURCE_DATE_EPOCH was set, but
+		// reproducible was requested explicitly: fall back to a fixed
+		// timestamp rather than os.Stat's wall-clock time, so the toggle
+		// alone is enough to get byte-identical output.
+		mtime = time.Unix(0, 0).UTC()
+	}
+
+	// Every create() call in this batch shares the same fpm entry, so the
+	// same reproducible/mtime applies to all of them: set SOURCE_DATE_EPOCH
+	// once for the whole batch instead of per-call, so the formats/platforms
+	// below can still run concurrently under ctx.Parallelism rather than
+	// being serialized by a lock held around each individual packager.Package.
+	restoreSourceDateEpoch, err := withSourceDateEpoch(reproducible, mtime)
+	if err != nil {
+		return err
+	}
+	defer restoreSourceDateEpoch()
+
 	g := semerrgroup.New(ctx.Parallelism)
 	for _, format := range fpm.Formats {
 		for _, artifacts := range linuxBinaries {
 			format := format
 			artifacts := artifacts
 			g.Go(func() error {
-				return create(ctx, fpm, format, artifacts)
+				return create(ctx, fpm, format, artifacts, reproducible, mtime)
 			})
 		}
 	}
@@ -139,7 +208,107 @@ func isSupportedTermuxArch(arch string) bool {
 	return false
 }
 
-func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*artifact.Artifact) error {
+const ipkFormat = "ipk"
+
+// ipkArchReplacer maps Go arch names to the arch names used by the ipk
+// (opkg) feed conventions, mirroring what OpenWrt/Yocto expect.
+var ipkArchReplacer = strings.NewReplacer(
+	"386", "i386",
+	"amd64", "x86_64",
+	"arm64", "aarch64_generic",
+)
+
+const archlinuxFormat = "archlinux"
+
+// isSupportedArchlinuxArch reports whether arch is supported by the upstream
+// arch packager, which today only officially supports amd64.
+func isSupportedArchlinuxArch(arch string) bool {
+	return strings.HasPrefix(arch, "amd64")
+}
+
+// resolveMTime figures out the timestamp to stamp reproducible packages
+// with, preferring an explicit nfpms.mtime over SOURCE_DATE_EPOCH. It
+// returns the zero Time if neither is set.
+func resolveMTime(ctx *context.Context, t *tmpl.Template, fpm config.NFPM) (time.Time, error) {
+	if fpm.Mtime != "" {
+		mtime, err := t.Apply(fpm.Mtime)
+		if err != nil {
+			return time.Time{}, err
+		}
+		parsed, err := time.Parse(time.RFC3339, mtime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid nfpms.mtime %q: %w", mtime, err)
+		}
+		return parsed, nil
+	}
+	epoch := ctx.Env["SOURCE_DATE_EPOCH"]
+	if epoch == "" {
+		return time.Time{}, nil
+	}
+	sec, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// withSourceDateEpoch exports SOURCE_DATE_EPOCH for the duration of the
+// batch of packages built from a single nfpms entry, so nfpm's signing (and
+// any other tooling it shells out to) picks up the pinned timestamp instead
+// of the current wall-clock time, per the reproducible-builds.org
+// convention. This is what suppresses embedding a fresh signature timestamp
+// on every run.
+//
+// It's called once per doRun, before that entry's formats/platforms are
+// packaged concurrently, rather than wrapped around each individual
+// packager.Package call: every create() in the batch shares the same
+// fpm.Reproducible/mtime, so there's nothing per-call left to snapshot, and
+// serializing the whole package+sign step behind a lock would silently
+// collapse ctx.Parallelism to 1 for that entry. The returned restore func
+// must be called once the batch is done.
+func withSourceDateEpoch(reproducible bool, mtime time.Time) (func(), error) {
+	if !reproducible {
+		return func() {}, nil
+	}
+
+	prev, hadPrev := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if err := os.Setenv("SOURCE_DATE_EPOCH", strconv.FormatInt(mtime.Unix(), 10)); err != nil {
+		return func() {}, err
+	}
+	return func() {
+		if hadPrev {
+			os.Setenv("SOURCE_DATE_EPOCH", prev)
+		} else {
+			os.Unsetenv("SOURCE_DATE_EPOCH")
+		}
+	}, nil
+}
+
+// makeReproducible sorts contents by destination so package layout no longer
+// depends on discovery order, and pins the mtime, uid and gid of every entry
+// that doesn't already set one, so two runs against the same commit produce
+// byte-identical packages.
+func makeReproducible(contents files.Contents, mtime time.Time) {
+	sort.Slice(contents, func(i, j int) bool {
+		return contents[i].Destination < contents[j].Destination
+	})
+	for _, c := range contents {
+		if c.FileInfo == nil {
+			c.FileInfo = &files.ContentFileInfo{}
+		}
+		if !mtime.IsZero() && c.FileInfo.MTime.IsZero() {
+			c.FileInfo.MTime = mtime
+		}
+		if c.FileInfo.Owner == "" {
+			c.FileInfo.Owner = "root"
+		}
+		if c.FileInfo.Group == "" {
+			c.FileInfo.Group = "root"
+		}
+	}
+}
+
+func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*artifact.Artifact, reproducible bool, mtime time.Time) error {
 	// TODO: improve mips handling on nfpm
 	infoArch := binaries[0].Goarch + binaries[0].Goarm + binaries[0].Gomips // key used for the ConventionalFileName et al
 	arch := infoArch + binaries[0].Goamd64                                  // unique arch key
@@ -169,6 +338,16 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 		bindDir = filepath.Join("/data/data/com.termux/files", bindDir)
 	}
 
+	if format == ipkFormat {
+		infoArch = ipkArchReplacer.Replace(infoArch)
+		arch = ipkArchReplacer.Replace(arch)
+	}
+
+	if format == archlinuxFormat && !isSupportedArchlinuxArch(arch) {
+		log.Debugf("skipping archlinux for %s as its not supported by the arch packager", arch)
+		return nil
+	}
+
 	overridden, err := mergeOverrides(fpm, format)
 	if err != nil {
 		return err
@@ -202,17 +381,35 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 		return err
 	}
 
-	debKeyFile, err := t.Apply(overridden.Deb.Signature.KeyFile)
+	debKeyID, err := resolveKeyID(ctx, t, overridden.Deb.Signature.KeyID, "DEB", fpm.ID)
+	if err != nil {
+		return err
+	}
+
+	debKeyFile, cleanupDebKeyFile, err := resolveSigningKeyFile(ctx, t, overridden.Deb.Signature.KeyFile, debKeyID)
+	defer cleanupDebKeyFile()
+	if err != nil {
+		return err
+	}
+
+	rpmKeyID, err := resolveKeyID(ctx, t, overridden.RPM.Signature.KeyID, "RPM", fpm.ID)
 	if err != nil {
 		return err
 	}
 
-	rpmKeyFile, err := t.Apply(overridden.RPM.Signature.KeyFile)
+	rpmKeyFile, cleanupRPMKeyFile, err := resolveSigningKeyFile(ctx, t, overridden.RPM.Signature.KeyFile, rpmKeyID)
+	defer cleanupRPMKeyFile()
 	if err != nil {
 		return err
 	}
 
-	apkKeyFile, err := t.Apply(overridden.APK.Signature.KeyFile)
+	apkKeyID, err := resolveKeyID(ctx, t, overridden.APK.Signature.KeyID, "APK", fpm.ID)
+	if err != nil {
+		return err
+	}
+
+	apkKeyFile, cleanupAPKKeyFile, err := resolveSigningKeyFile(ctx, t, overridden.APK.Signature.KeyFile, apkKeyID)
+	defer cleanupAPKKeyFile()
 	if err != nil {
 		return err
 	}
@@ -222,6 +419,16 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 		return err
 	}
 
+	archlinuxPkgbase, err := t.Apply(overridden.ArchLinux.Pkgbase)
+	if err != nil {
+		return err
+	}
+
+	archlinuxPackager, err := t.Apply(overridden.ArchLinux.Packager)
+	if err != nil {
+		return err
+	}
+
 	contents := files.Contents{}
 	for _, content := range overridden.Contents {
 		src, err := t.Apply(content.Source)
@@ -283,6 +490,10 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 		}
 	}
 
+	if reproducible {
+		makeReproducible(contents, mtime)
+	}
+
 	log.WithField("files", destinations(contents)).Debug("all archive files")
 
 	info := &nfpm.Info{
@@ -335,7 +546,8 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 					PackageSignature: nfpm.PackageSignature{
 						KeyFile:       debKeyFile,
 						KeyPassphrase: getPassphraseFromEnv(ctx, "DEB", fpm.ID),
-						// TODO: Method, Type, KeyID
+						KeyID:         debKeyID,
+						Method:        overridden.Deb.Signature.Method,
 					},
 					Type: overridden.Deb.Signature.Type,
 				},
@@ -348,7 +560,7 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 					PackageSignature: nfpm.PackageSignature{
 						KeyFile:       rpmKeyFile,
 						KeyPassphrase: getPassphraseFromEnv(ctx, "RPM", fpm.ID),
-						// TODO: KeyID
+						KeyID:         rpmKeyID,
 					},
 				},
 				Scripts: nfpm.RPMScripts{
@@ -361,6 +573,7 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 					PackageSignature: nfpm.PackageSignature{
 						KeyFile:       apkKeyFile,
 						KeyPassphrase: getPassphraseFromEnv(ctx, "APK", fpm.ID),
+						KeyID:         apkKeyID,
 					},
 					KeyName: apkKeyName,
 				},
@@ -370,13 +583,21 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 				},
 			},
 			ArchLinux: nfpm.ArchLinux{
-				Pkgbase:  overridden.ArchLinux.Pkgbase,
-				Packager: overridden.ArchLinux.Packager,
+				Pkgbase:  archlinuxPkgbase,
+				Packager: archlinuxPackager,
 				Scripts: nfpm.ArchLinuxScripts{
 					PreUpgrade:  overridden.ArchLinux.Scripts.PreUpgrade,
 					PostUpgrade: overridden.ArchLinux.Scripts.PostUpgrade,
 				},
 			},
+			// IPK feeds are signed at the feed level (e.g. via opkg-make-index),
+			// so, unlike Deb/RPM/APK, there's no per-package signature to wire
+			// here. opkg's preinst/postinst/prerm/postrm scripts are already
+			// covered by the common Scripts block above.
+			IPK: nfpm.IPK{
+				ABIVersion: overridden.IPK.ABIVersion,
+				Predepends: overridden.IPK.Predepends,
+			},
 		},
 	}
 
@@ -424,7 +645,7 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 	if err := w.Close(); err != nil {
 		return fmt.Errorf("could not close package file: %w", err)
 	}
-	ctx.Artifacts.Add(&artifact.Artifact{
+	pkg := &artifact.Artifact{
 		Type:    artifact.LinuxPackage,
 		Name:    name,
 		Path:    path,
@@ -439,7 +660,15 @@ func create(ctx *context.Context, fpm config.NFPM, format string, binaries []*ar
 			artifact.ExtraFormat: format,
 			extraFiles:           contents,
 		},
-	})
+	}
+	ctx.Artifacts.Add(pkg)
+
+	if overridden.SBOM.Format != "" {
+		if err := generateSBOM(ctx, t, overridden.SBOM, fpm.ID, info, pkg, contents); err != nil {
+			return fmt.Errorf("failed to generate sbom for %s: %w", name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -469,3 +698,261 @@ func getPassphraseFromEnv(ctx *context.Context, packager string, nfpmID string)
 
 	return passphrase
 }
+
+func getKeyIDFromEnv(ctx *context.Context, packager string, nfpmID string) string {
+	nfpmID = strings.ToUpper(nfpmID)
+	if keyID := ctx.Env[fmt.Sprintf("NFPM_%s_%s_KEYID", nfpmID, packager)]; keyID != "" {
+		return keyID
+	}
+	return ctx.Env[fmt.Sprintf("NFPM_%s_KEYID", nfpmID)]
+}
+
+// resolveKeyID applies the templated KeyID override, falling back to the
+// NFPM_<ID>_<FORMAT>_KEYID/NFPM_<ID>_KEYID env vars, the same pattern used
+// for signature passphrases.
+func resolveKeyID(ctx *context.Context, t *tmpl.Template, keyID, packager, nfpmID string) (string, error) {
+	applied, err := t.Apply(keyID)
+	if err != nil {
+		return "", err
+	}
+	if applied != "" {
+		return applied, nil
+	}
+	return getKeyIDFromEnv(ctx, packager, nfpmID), nil
+}
+
+// noopCleanup is returned alongside key files we didn't create ourselves, so
+// callers can unconditionally defer the cleanup func returned by
+// resolveSigningKeyFile without caring where the key file came from.
+func noopCleanup() error { return nil }
+
+// resolveSigningKeyFile templates the configured key file path. If none is
+// set but a key ID is available, it exports the matching secret key from the
+// local GnuPG keyring instead, so signing can be driven entirely by a
+// keyring plus a key ID rather than an inline key file. The returned cleanup
+// func removes the temporary key file once the caller is done signing with
+// it; it is a no-op when the key file wasn't exported by us.
+func resolveSigningKeyFile(ctx *context.Context, t *tmpl.Template, keyFile, keyID string) (string, func() error, error) {
+	applied, err := t.Apply(keyFile)
+	if err != nil {
+		return "", noopCleanup, err
+	}
+	if applied != "" {
+		return applied, noopCleanup, nil
+	}
+	if keyID == "" {
+		return "", noopCleanup, nil
+	}
+	return exportKeyFromKeyring(ctx, keyID)
+}
+
+// exportKeyFromKeyring exports the given secret key from the local GnuPG
+// keyring (via `gpg --export-secret-key`) into a temporary file and returns
+// its path along with a cleanup func that removes it.
+func exportKeyFromKeyring(ctx *context.Context, keyID string) (string, func() error, error) {
+	f, err := os.CreateTemp("", "goreleaser-nfpm-key-*")
+	if err != nil {
+		return "", noopCleanup, fmt.Errorf("could not create temporary key file: %w", err)
+	}
+	defer f.Close()
+
+	cleanup := func() error { return os.Remove(f.Name()) }
+
+	cmd := exec.CommandContext(ctx, "gpg", "--batch", "--yes", "--export-secret-key", keyID)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", cleanup, fmt.Errorf("could not export key %q from keyring: %w", keyID, err)
+	}
+	if _, err := f.Write(out); err != nil {
+		return "", cleanup, fmt.Errorf("could not write key file: %w", err)
+	}
+	return f.Name(), cleanup, nil
+}
+
+const extraLinuxPackage = "LinuxPackage"
+
+const (
+	sbomFormatSPDX      = "spdx-json"
+	sbomFormatCycloneDX = "cyclonedx-json"
+)
+
+type sbomChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+func checksumsFor(path string) ([]sbomChecksum, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sha256Sum := sha256.New()
+	sha1Sum := sha1.New()
+	md5Sum := md5.New()
+	if _, err := io.Copy(io.MultiWriter(sha256Sum, sha1Sum, md5Sum), f); err != nil {
+		return nil, fmt.Errorf("could not hash %s: %w", path, err)
+	}
+	return []sbomChecksum{
+		{Algorithm: "SHA256", Value: hex.EncodeToString(sha256Sum.Sum(nil))},
+		{Algorithm: "SHA1", Value: hex.EncodeToString(sha1Sum.Sum(nil))},
+		{Algorithm: "MD5", Value: hex.EncodeToString(md5Sum.Sum(nil))},
+	}, nil
+}
+
+// spdxFile and spdxDocument are a deliberately minimal rendering of the SPDX
+// 2.3 JSON schema: just enough package metadata and per-file checksums to
+// describe what went into an nfpm package.
+type spdxFile struct {
+	FileName  string         `json:"fileName"`
+	Checksums []sbomChecksum `json:"checksums"`
+}
+
+type spdxDocument struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	DataLicense       string `json:"dataLicense"`
+	SPDXID            string `json:"SPDXID"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	CreationInfo      struct {
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	PackageVersion  string     `json:"versionInfo"`
+	PackageSupplier string     `json:"supplier,omitempty"`
+	PackageLicense  string     `json:"licenseDeclared,omitempty"`
+	PackageHomepage string     `json:"homepage,omitempty"`
+	Files           []spdxFile `json:"files"`
+}
+
+// cdxComponent and cyclonedxDocument are a minimal rendering of the
+// CycloneDX 1.4 JSON schema.
+type cdxComponent struct {
+	Type   string         `json:"type"`
+	Name   string         `json:"name"`
+	Hashes []sbomChecksum `json:"hashes"`
+}
+
+type cyclonedxDocument struct {
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Version     int    `json:"version"`
+	Metadata    struct {
+		Component struct {
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Version   string `json:"version"`
+			Publisher string `json:"publisher,omitempty"`
+			Licenses  []struct {
+				License struct {
+					Name string `json:"name"`
+				} `json:"license"`
+			} `json:"licenses,omitempty"`
+		} `json:"component"`
+	} `json:"metadata"`
+	Components []cdxComponent `json:"components"`
+}
+
+// sbomPath pairs the on-disk path to hash with the path as it will appear
+// inside the installed package, which is what the SBOM should describe.
+type sbomPath struct {
+	hashPath    string
+	installPath string
+}
+
+// generateSBOM builds an SPDX or CycloneDX document describing the binaries
+// and content files packaged into info, and registers it as an artifact.SBOM
+// linked to the package artifact that was just produced.
+func generateSBOM(ctx *context.Context, t *tmpl.Template, sbom config.NFPMSBOM, nfpmID string, info *nfpm.Info, pkg *artifact.Artifact, contents files.Contents) error {
+	// contents already includes the packaged binaries (create only omits
+	// them from contents when fpm.Meta is set), so there's no need to walk
+	// binaries separately here.
+	paths := make([]sbomPath, 0, len(contents))
+	for _, c := range contents {
+		paths = append(paths, sbomPath{hashPath: c.Source, installPath: c.Destination})
+	}
+
+	var doc any
+	switch sbom.Format {
+	case sbomFormatSPDX, "":
+		spdx := spdxDocument{
+			SPDXVersion:       "SPDX-2.3",
+			DataLicense:       "CC0-1.0",
+			SPDXID:            "SPDXRef-DOCUMENT",
+			Name:              info.Name,
+			DocumentNamespace: fmt.Sprintf("https://goreleaser.com/spdx/%s-%s", info.Name, info.Version),
+			PackageVersion:    info.Version,
+			PackageSupplier:   info.Maintainer,
+			PackageLicense:    info.License,
+			PackageHomepage:   info.Homepage,
+		}
+		spdx.CreationInfo.Creators = []string{"Tool: goreleaser"}
+		for _, p := range paths {
+			checksums, err := checksumsFor(p.hashPath)
+			if err != nil {
+				return err
+			}
+			spdx.Files = append(spdx.Files, spdxFile{FileName: p.installPath, Checksums: checksums})
+		}
+		doc = spdx
+	case sbomFormatCycloneDX:
+		cdx := cyclonedxDocument{
+			BOMFormat:   "CycloneDX",
+			SpecVersion: "1.4",
+			Version:     1,
+		}
+		cdx.Metadata.Component.Type = "application"
+		cdx.Metadata.Component.Name = info.Name
+		cdx.Metadata.Component.Version = info.Version
+		cdx.Metadata.Component.Publisher = info.Maintainer
+		for _, p := range paths {
+			checksums, err := checksumsFor(p.hashPath)
+			if err != nil {
+				return err
+			}
+			cdx.Components = append(cdx.Components, cdxComponent{
+				Type:   "file",
+				Name:   p.installPath,
+				Hashes: checksums,
+			})
+		}
+		doc = cdx
+	default:
+		return fmt.Errorf("invalid sbom format: %s", sbom.Format)
+	}
+
+	nameTemplate := sbom.NameTemplate
+	if nameTemplate == "" {
+		nameTemplate = "{{ .ArtifactName }}.sbom.json"
+	}
+	name, err := t.WithExtraFields(tmpl.Fields{
+		"ArtifactName": pkg.Name,
+	}).Apply(nameTemplate)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(ctx.Config.Dist, name)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("could not encode sbom: %w", err)
+	}
+
+	ctx.Artifacts.Add(&artifact.Artifact{
+		Type: artifact.SBOM,
+		Name: name,
+		Path: path,
+		Extra: map[string]interface{}{
+			artifact.ExtraID:  nfpmID,
+			extraLinuxPackage: pkg.Name,
+		},
+	})
+	return nil
+}
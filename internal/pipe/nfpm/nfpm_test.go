@@ -0,0 +1,148 @@
+package nfpm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goreleaser/goreleaser/internal/artifact"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+	"github.com/goreleaser/nfpm/v2"
+	"github.com/goreleaser/nfpm/v2/files"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKeyIDFromEnv(t *testing.T) {
+	t.Run("format specific", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		ctx.Env["NFPM_DEFAULT_DEB_KEYID"] = "format-specific"
+		ctx.Env["NFPM_DEFAULT_KEYID"] = "fallback"
+		require.Equal(t, "format-specific", getKeyIDFromEnv(ctx, "DEB", "default"))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		ctx.Env["NFPM_DEFAULT_KEYID"] = "fallback"
+		require.Equal(t, "fallback", getKeyIDFromEnv(ctx, "DEB", "default"))
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		ctx := context.New(config.Project{})
+		require.Empty(t, getKeyIDFromEnv(ctx, "DEB", "default"))
+	})
+}
+
+func TestResolveKeyID(t *testing.T) {
+	ctx := context.New(config.Project{})
+	ctx.Env["NFPM_DEFAULT_RPM_KEYID"] = "from-env"
+	tp := tmpl.New(ctx)
+
+	t.Run("configured", func(t *testing.T) {
+		got, err := resolveKeyID(ctx, tp, "configured", "RPM", "default")
+		require.NoError(t, err)
+		require.Equal(t, "configured", got)
+	})
+
+	t.Run("falls back to env", func(t *testing.T) {
+		got, err := resolveKeyID(ctx, tp, "", "RPM", "default")
+		require.NoError(t, err)
+		require.Equal(t, "from-env", got)
+	})
+}
+
+func TestResolveSigningKeyFileConfigured(t *testing.T) {
+	ctx := context.New(config.Project{})
+	tp := tmpl.New(ctx)
+
+	keyFile, cleanup, err := resolveSigningKeyFile(ctx, tp, "/keys/key.gpg", "")
+	require.NoError(t, err)
+	require.Equal(t, "/keys/key.gpg", keyFile)
+	require.NoError(t, cleanup())
+}
+
+func TestResolveSigningKeyFileNoKeyConfigured(t *testing.T) {
+	ctx := context.New(config.Project{})
+	tp := tmpl.New(ctx)
+
+	keyFile, cleanup, err := resolveSigningKeyFile(ctx, tp, "", "")
+	require.NoError(t, err)
+	require.Empty(t, keyFile)
+	require.NoError(t, cleanup())
+}
+
+func TestIsSupportedArchlinuxArch(t *testing.T) {
+	tests := []struct {
+		arch string
+		want bool
+	}{
+		{"amd64", true},
+		{"amd64v1", true},
+		{"arm64", false},
+		{"386", false},
+		{"arm64v8", false},
+	}
+	for _, tt := range tests {
+		if got := isSupportedArchlinuxArch(tt.arch); got != tt.want {
+			t.Errorf("isSupportedArchlinuxArch(%q) = %v, want %v", tt.arch, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateSBOMUsesDestinationPaths(t *testing.T) {
+	dist := t.TempDir()
+	src := filepath.Join(dist, "mybin")
+	require.NoError(t, os.WriteFile(src, []byte("binary contents"), 0o644))
+
+	ctx := context.New(config.Project{Dist: dist})
+	tp := tmpl.New(ctx)
+	sbom := config.NFPMSBOM{Format: sbomFormatSPDX}
+	info := &nfpm.Info{Name: "mypkg", Version: "1.0.0"}
+	pkg := &artifact.Artifact{Name: "mypkg_1.0.0_linux_amd64.deb"}
+	contents := files.Contents{
+		{Source: src, Destination: "/usr/bin/mybin"},
+	}
+
+	require.NoError(t, generateSBOM(ctx, tp, sbom, "default", info, pkg, contents))
+
+	sbomPath := filepath.Join(dist, "mypkg_1.0.0_linux_amd64.deb.sbom.json")
+	raw, err := os.ReadFile(sbomPath)
+	require.NoError(t, err)
+
+	var doc spdxDocument
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	require.Len(t, doc.Files, 1)
+	require.Equal(t, "/usr/bin/mybin", doc.Files[0].FileName)
+}
+
+func TestWithSourceDateEpoch(t *testing.T) {
+	t.Run("not reproducible leaves env untouched", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "123")
+		restore, err := withSourceDateEpoch(false, time.Time{})
+		require.NoError(t, err)
+		restore()
+		require.Equal(t, "123", os.Getenv("SOURCE_DATE_EPOCH"))
+	})
+
+	t.Run("sets and restores a previously unset value", func(t *testing.T) {
+		require.NoError(t, os.Unsetenv("SOURCE_DATE_EPOCH"))
+		restore, err := withSourceDateEpoch(true, time.Unix(1700000000, 0).UTC())
+		require.NoError(t, err)
+		require.Equal(t, "1700000000", os.Getenv("SOURCE_DATE_EPOCH"))
+		restore()
+		_, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+		require.False(t, ok)
+	})
+
+	t.Run("restores a previously set value", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "1")
+		restore, err := withSourceDateEpoch(true, time.Unix(2, 0).UTC())
+		require.NoError(t, err)
+		require.Equal(t, "2", os.Getenv("SOURCE_DATE_EPOCH"))
+		restore()
+		require.Equal(t, "1", os.Getenv("SOURCE_DATE_EPOCH"))
+	})
+}
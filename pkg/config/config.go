@@ -22,10 +22,31 @@ type NFPM struct {
 	Overrides        map[string]NFPMOverridables `yaml:"overrides,omitempty"`
 	Deb              NFPMDeb                     `yaml:"deb,omitempty"`
 
+	// Mtime is an RFC3339 timestamp (or template resolving to one) to stamp
+	// every packaged file with, for reproducible builds. Takes precedence
+	// over SOURCE_DATE_EPOCH when both are set.
+	Mtime string `yaml:"mtime,omitempty"`
+	// Reproducible sorts package contents deterministically, pins uid/gid
+	// to root/root, and suppresses signature timestamps, so two runs
+	// against the same commit produce byte-identical packages.
+	Reproducible bool `yaml:"reproducible,omitempty"`
+
 	// Deprecated: use Replacements in NFPMOverridables instead.
 	Replacements map[string]string `yaml:"replacements,omitempty"`
 }
 
+// NFPMSBOM configures the Software Bill of Materials generated alongside
+// the package. It lives in NFPMOverridables rather than NFPM so it can be
+// set per-format via nfpms[].overrides[format], same as everything else
+// that only makes sense per package.
+type NFPMSBOM struct {
+	// Format is either "spdx-json" or "cyclonedx-json". Leave empty to
+	// disable SBOM generation for this nfpms entry.
+	Format string `yaml:"format,omitempty"`
+	// NameTemplate defaults to "{{ .ArtifactName }}.sbom.json".
+	NameTemplate string `yaml:"name_template,omitempty"`
+}
+
 // NFPMDeb contains deb-specific settings that apply to the whole nfpms
 // entry, regardless of format overrides.
 type NFPMDeb struct {
@@ -49,11 +70,13 @@ type NFPMOverridables struct {
 	Provides         []string          `yaml:"provides,omitempty"`
 	Contents         []NFPMContent     `yaml:"contents,omitempty"`
 	Scripts          NFPMScripts       `yaml:"scripts,omitempty"`
+	SBOM             NFPMSBOM          `yaml:"sbom,omitempty"`
 
 	RPM       NFPMRPM             `yaml:"rpm,omitempty"`
 	Deb       NFPMOverridablesDeb `yaml:"deb,omitempty"`
 	APK       NFPMAPK             `yaml:"apk,omitempty"`
 	ArchLinux NFPMArchLinux       `yaml:"archlinux,omitempty"`
+	IPK       NFPMIPK             `yaml:"ipk,omitempty"`
 }
 
 // NFPMContent describes a single file to add to the package.
@@ -91,6 +114,7 @@ type NFPMRPMScripts struct {
 // NFPMRPMSignature config.
 type NFPMRPMSignature struct {
 	KeyFile string `yaml:"key_file,omitempty"`
+	KeyID   string `yaml:"key_id,omitempty"`
 }
 
 // NFPMOverridablesDeb is custom configs that are only available on deb packages.
@@ -121,7 +145,10 @@ type NFPMDebTriggers struct {
 // NFPMDebSignature config.
 type NFPMDebSignature struct {
 	KeyFile string `yaml:"key_file,omitempty"`
-	Type    string `yaml:"type,omitempty"` // origin, maint or archive
+	KeyID   string `yaml:"key_id,omitempty"`
+	// Method is the signing tool used, e.g. "dpkg-sig" or "debsign".
+	Method string `yaml:"method,omitempty"`
+	Type   string `yaml:"type,omitempty"` // origin, maint or archive
 }
 
 // NFPMAPK is custom configs that are only available on APK packages.
@@ -139,6 +166,7 @@ type NFPMAPKScripts struct {
 // NFPMAPKSignature config.
 type NFPMAPKSignature struct {
 	KeyFile string `yaml:"key_file,omitempty"`
+	KeyID   string `yaml:"key_id,omitempty"`
 	KeyName string `yaml:"key_name,omitempty"`
 }
 
@@ -154,3 +182,16 @@ type NFPMArchLinuxScripts struct {
 	PreUpgrade  string `yaml:"preupgrade,omitempty"`
 	PostUpgrade string `yaml:"postupgrade,omitempty"`
 }
+
+// NFPMIPK is custom configs that are only available on ipk packages.
+//
+// ipk feeds are signed at the feed level (via opkg-make-index), not
+// per-package, so there's no equivalent of Deb/RPM/APK's Signature block
+// here. Unlike apk/pacman, opkg's package lifecycle is dpkg-style
+// (preinst/postinst/prerm/postrm), which is already covered by the common
+// NFPMScripts block above, so there's no separate upgrade-transaction
+// hook to model here either.
+type NFPMIPK struct {
+	ABIVersion string   `yaml:"abi_version,omitempty"`
+	Predepends []string `yaml:"predepends,omitempty"`
+}